@@ -0,0 +1,129 @@
+package construct
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Codec frames Messages onto a connection. construct negotiates a codec per
+// connection at auth time, so servers that predate negotiation keep working
+// against LegacyDelimitedCodec while upgraded servers can opt into the more
+// robust LengthPrefixedCodec.
+type Codec interface {
+	// Name identifies the codec during version negotiation.
+	Name() string
+	// Encode frames msg for the wire.
+	Encode(msg Message) ([]byte, error)
+	// Decode reads the next framed message off r and returns its raw JSON
+	// payload.
+	Decode(r *bufio.Reader) (string, error)
+}
+
+// LegacyDelimitedCodec is the original framing: a JSON payload followed by a
+// literal delimiter string, scanned for byte-by-byte. It is fragile because
+// any '-' byte inside the JSON payload (a negative number, a string value)
+// can trip the scan early, but it remains the default so servers that don't
+// speak the version handshake keep working unmodified.
+type LegacyDelimitedCodec struct {
+	Delimiter string
+}
+
+func (c LegacyDelimitedCodec) Name() string { return "legacy" }
+
+func (c LegacyDelimitedCodec) Encode(msg Message) ([]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, []byte(c.Delimiter)...), nil
+}
+
+func (c LegacyDelimitedCodec) Decode(r *bufio.Reader) (string, error) {
+	var builder strings.Builder
+	for {
+		line, err := r.ReadString('-')
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(line)
+		if strings.Contains(line, c.Delimiter) {
+			break
+		}
+	}
+	full := strings.ReplaceAll(builder.String(), c.Delimiter, "")
+	return strings.TrimSpace(full), nil
+}
+
+// LengthPrefixedCodec frames each message as a 4-byte big-endian length
+// followed by that many bytes of JSON, redcon-style bulk framing that can't
+// be confused by delimiter-like bytes inside the payload.
+type LengthPrefixedCodec struct{}
+
+func (c LengthPrefixedCodec) Name() string { return "length" }
+
+func (c LengthPrefixedCodec) Encode(msg Message) ([]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(data)))
+	copy(buf[4:], data)
+	return buf, nil
+}
+
+func (c LengthPrefixedCodec) Decode(r *bufio.Reader) (string, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return "", err
+	}
+	n := binary.BigEndian.Uint32(lenBuf[:])
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// negotiateCodec asks the server to switch framing to preferred via a
+// negotiate_codec message sent over the legacy codec (every server, old or
+// new, understands legacy framing). It falls back to LegacyDelimitedCodec on
+// any error, timeout, or refusal so older servers keep working unmodified.
+func negotiateCodec(conn net.Conn, reader *bufio.Reader, delimiter string, preferred Codec) Codec {
+	legacy := LegacyDelimitedCodec{Delimiter: delimiter}
+	if preferred == nil || preferred.Name() == legacy.Name() {
+		return legacy
+	}
+
+	data, err := legacy.Encode(Message{"type": "negotiate_codec", "codec": preferred.Name()})
+	if err != nil {
+		return legacy
+	}
+	if _, err := conn.Write(data); err != nil {
+		return legacy
+	}
+
+	conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+	raw, err := legacy.Decode(reader)
+	if err != nil {
+		return legacy
+	}
+
+	var ack Message
+	if err := json.Unmarshal([]byte(raw), &ack); err == nil {
+		if ok, _ := ack["ok"].(bool); ok {
+			if name, _ := ack["codec"].(string); name == preferred.Name() {
+				return preferred
+			}
+		}
+	}
+
+	fmt.Printf("[Codec] server does not support %q codec, falling back to legacy framing\n", preferred.Name())
+	return legacy
+}
@@ -0,0 +1,117 @@
+package construct
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestLengthPrefixedCodecRoundTrip(t *testing.T) {
+	c := LengthPrefixedCodec{}
+	msg := Message{"type": "apply_force", "cube_name": "fox-1", "force": []float64{-1, 0, 1}}
+
+	data, err := c.Encode(msg)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	raw, err := c.Decode(bufio.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if raw == "" {
+		t.Fatal("Decode returned empty payload")
+	}
+}
+
+// TestLengthPrefixedCodecSurvivesEmbeddedDelimiter exercises the exact case
+// that motivated this codec: a payload containing the legacy delimiter byte
+// ('-', from a negative number) must still frame correctly, unlike
+// LegacyDelimitedCodec's scan-for-delimiter approach.
+func TestLengthPrefixedCodecSurvivesEmbeddedDelimiter(t *testing.T) {
+	c := LengthPrefixedCodec{}
+	msg := Message{"force": []float64{-5, -10, -15}}
+
+	data, err := c.Encode(msg)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	// Append a second message to prove the reader stops exactly at the
+	// length-prefixed boundary instead of scanning into it.
+	second := Message{"type": "ping"}
+	secondData, err := c.Encode(second)
+	if err != nil {
+		t.Fatalf("Encode second: %v", err)
+	}
+
+	r := bufio.NewReader(bytes.NewReader(append(append([]byte{}, data...), secondData...)))
+	first, err := c.Decode(r)
+	if err != nil {
+		t.Fatalf("Decode first: %v", err)
+	}
+	if !bytes.Contains([]byte(first), []byte("-5")) {
+		t.Fatalf("decoded payload lost the embedded '-' bytes: %q", first)
+	}
+
+	rest, err := c.Decode(r)
+	if err != nil {
+		t.Fatalf("Decode second: %v", err)
+	}
+	if !bytes.Contains([]byte(rest), []byte("ping")) {
+		t.Fatalf("second message was consumed/mangled by the first Decode: %q", rest)
+	}
+}
+
+func TestLegacyDelimitedCodecRoundTrip(t *testing.T) {
+	c := LegacyDelimitedCodec{Delimiter: "-"}
+	msg := Message{"type": "ping"}
+
+	data, err := c.Encode(msg)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	raw, err := c.Decode(bufio.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if raw == "" {
+		t.Fatal("Decode returned empty payload")
+	}
+}
+
+func TestNegotiateCodecFallsBackWhenServerDoesNotAck(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	// Drain whatever the client writes and never reply, simulating an old
+	// server that doesn't understand negotiate_codec.
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			if _, err := serverConn.Read(buf); err != nil {
+				return
+			}
+		}
+	}()
+
+	reader := bufio.NewReader(clientConn)
+	got := negotiateCodec(clientConn, reader, "-", LengthPrefixedCodec{})
+	if got.Name() != "legacy" {
+		t.Fatalf("negotiateCodec = %q, want fallback to %q", got.Name(), "legacy")
+	}
+}
+
+func TestNegotiateCodecReturnsLegacyWhenPreferredIsLegacy(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	reader := bufio.NewReader(clientConn)
+	got := negotiateCodec(clientConn, reader, "-", nil)
+	if got.Name() != "legacy" {
+		t.Fatalf("negotiateCodec with nil preferred = %q, want %q", got.Name(), "legacy")
+	}
+}
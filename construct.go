@@ -1,6 +1,7 @@
 package construct
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -21,7 +22,44 @@ type Cube[T paragon.Numeric] struct {
 	ClampMin   float64
 	ClampMax   float64
 	Debug      bool
-	conn       net.Conn
+
+	// Pool, if set, is used to check out the cube's persistent connection
+	// instead of dialing directly, so callers can cap total sockets to the
+	// sim server across many cubes.
+	Pool *Pool
+
+	// Codec is the preferred framing to negotiate when dialing directly
+	// (i.e. when Pool is nil). Defaults to LegacyDelimitedCodec if left nil.
+	// Ignored when Pool is set, since the pool negotiates its own codec.
+	Codec Codec
+
+	// Metrics, if set, records spawn/despawn/apply_force counters, pulse and
+	// refresh latency, and position gauges for this cube. SpawnAll copies
+	// Construct.Metrics onto every cube it spawns.
+	Metrics *Metrics
+
+	// shardKey is the cube name as it was hashed against the shard ring at
+	// spawn time, captured before Spawn appends "_BASE" to Name. Sharding
+	// bookkeeping (ownersByName, rebalance) keys off this instead of Name so
+	// a rename doesn't silently move the cube to a different ring position
+	// than the one it actually got spawned on. Empty when c isn't sharded.
+	shardKey string
+
+	// posMu guards Position and subscribed once Subscribe has been called,
+	// since a background goroutine then writes Position concurrently with
+	// PulseWithModel/metrics reading it.
+	posMu      sync.Mutex
+	subscribed bool
+	unsub      chan struct{}
+
+	// connMu guards conn, reader, codec, and pc, which subscribeLoop's
+	// reconnect swaps out from its own goroutine concurrently with
+	// PulseWithModel/RefreshPosition reading them from the caller's.
+	connMu sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	codec  Codec
+	pc     *PoolConn
 }
 
 type Construct[T paragon.Numeric] struct {
@@ -31,6 +69,88 @@ type Construct[T paragon.Numeric] struct {
 	Cubes      []*Cube[T] // Array of cubes
 	ClampMin   float64
 	ClampMax   float64
+
+	// Pool, if set, is used by one-shot admin calls (DestroyAllCubes,
+	// UnfreezeAll, GetAllCubeNames) instead of dialing a fresh connection
+	// per call. It is created lazily with sane defaults on first use if left
+	// nil.
+	Pool *Pool
+
+	// Codec is the preferred framing to negotiate for connections the lazily
+	// created Pool opens. Defaults to LegacyDelimitedCodec if left nil.
+	// Ignored once Pool is explicitly set by the caller.
+	Codec Codec
+
+	// Servers, if non-empty, puts the Construct in sharded mode: each cube is
+	// routed to one of these servers via a consistent-hash ring keyed on its
+	// name (or HashTag), instead of every cube living on ServerAddr.
+	Servers []ServerEndpoint
+
+	// Metrics, if set, is propagated to every cube spawned through SpawnAll
+	// and drives the pool-in-use gauge for every pool this Construct opens.
+	Metrics *Metrics
+
+	mu         sync.Mutex
+	ring       *shardRing
+	shardPools map[string]*Pool
+}
+
+// sharded reports whether this Construct is in multi-server mode.
+func (c *Construct[T]) sharded() bool {
+	return len(c.Servers) > 0
+}
+
+// allPools returns every per-shard pool, building the ring and pools from
+// c.Servers on first use.
+func (c *Construct[T]) allPools() []*Pool {
+	c.mu.Lock()
+	if c.ring == nil {
+		c.ring = newShardRing()
+		for i := range c.Servers {
+			ep := c.Servers[i]
+			c.ring.add(&ep)
+		}
+	}
+	c.mu.Unlock()
+
+	pools := make([]*Pool, 0, len(c.Servers))
+	for i := range c.Servers {
+		pools = append(pools, c.poolFor(&c.Servers[i]))
+	}
+	return pools
+}
+
+// pool returns c.Pool, lazily creating one targeting c.ServerAddr if the
+// caller hasn't configured one explicitly, sized to cover every cube's
+// persistent connection (the same way poolFor sizes a shard's pool) plus
+// headroom for concurrent admin calls, rather than a flat default that a
+// Construct with hundreds of cubes would blow straight through.
+func (c *Construct[T]) pool() *Pool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Pool == nil {
+		size := len(c.Cubes) + shardPoolHeadroom
+		if size < defaultPoolSize {
+			size = defaultPoolSize
+		}
+		p := NewPool(c.ServerAddr, c.AuthPass, c.Delimiter, size, defaultIdleTimeout)
+		p.Codec = c.Codec
+		c.wireMetrics(p)
+		c.Pool = p
+	}
+	return c.Pool
+}
+
+// wireMetrics attaches c.Metrics' pool-in-use gauge to p, if metrics are
+// enabled.
+func (c *Construct[T]) wireMetrics(p *Pool) {
+	if c.Metrics == nil {
+		return
+	}
+	p.StatsHook = func(stats PoolStats) {
+		c.Metrics.observePoolStats(p.ServerAddr, stats)
+	}
 }
 
 type Message map[string]interface{}
@@ -49,35 +169,74 @@ func NewCube[T paragon.Numeric](name, unitName string, pos []float64, model *par
 
 func (c *Construct[T]) SpawnAll() {
 	for _, cube := range c.Cubes {
+		if c.sharded() {
+			ep := c.shardFor(cube.Name)
+			if ep == nil {
+				fmt.Printf("❌ [%s] no shard owns this cube name\n", cube.Name)
+				continue
+			}
+			cube.shardKey = cube.Name
+			cube.ServerAddr = ep.Addr
+			cube.AuthPass = ep.AuthPass
+			cube.Delimiter = ep.Delimiter
+			cube.Pool = c.poolFor(ep)
+		} else {
+			cube.Pool = c.pool()
+		}
+		if c.Metrics != nil {
+			cube.Metrics = c.Metrics
+		}
+		if cube.ClampMin == 0 && cube.ClampMax == 0 {
+			cube.ClampMin = c.ClampMin
+			cube.ClampMax = c.ClampMax
+		}
 		if err := cube.Spawn(); err != nil {
 			fmt.Println(err)
 		}
 	}
 }
 
+// targetPools returns the pool(s) admin calls should fan out to: every shard
+// pool when sharded, or the single lazily-created default pool otherwise.
+func (c *Construct[T]) targetPools() []*Pool {
+	if c.sharded() {
+		return c.allPools()
+	}
+	return []*Pool{c.pool()}
+}
+
 func (c *Construct[T]) DestroyAllCubes() {
-	conn, err := net.Dial("tcp", c.ServerAddr)
-	if err != nil {
-		fmt.Println("[Nuke] Failed to connect:", err)
-		return
+	var wg sync.WaitGroup
+	for _, pool := range c.targetPools() {
+		wg.Add(1)
+		go func(pool *Pool) {
+			defer wg.Done()
+			destroyAllCubesOnPool(pool)
+		}(pool)
 	}
-	defer conn.Close()
+	wg.Wait()
+}
 
-	if _, err := conn.Write([]byte(c.AuthPass + c.Delimiter)); err != nil {
-		fmt.Println("[Nuke] Failed to auth:", err)
+func destroyAllCubesOnPool(pool *Pool) {
+	pc, err := pool.Get()
+	if err != nil {
+		fmt.Println("[Nuke] Failed to connect:", err)
 		return
 	}
-	_, _ = readResponse(conn, c.Delimiter)
+	broken := false
+	defer func() { pool.Put(pc, broken) }()
 
 	const maxRetries = 5
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if err := sendJSONMessage(conn, Message{"type": "get_cube_list"}, c.Delimiter); err != nil {
+		if err := pc.Send(Message{"type": "get_cube_list"}); err != nil {
 			fmt.Println("[Nuke] Failed to request cube list:", err)
+			broken = true
 			return
 		}
-		raw, err := readResponse(conn, c.Delimiter)
+		raw, err := pc.Recv()
 		if err != nil {
 			fmt.Println("[Nuke] Failed to read cube list:", err)
+			broken = true
 			return
 		}
 
@@ -94,44 +253,53 @@ func (c *Construct[T]) DestroyAllCubes() {
 		}
 
 		for _, cube := range cubes {
-			if err := sendJSONMessage(conn, Message{
+			if err := pc.Send(Message{
 				"type":      "despawn_cube",
 				"cube_name": cube,
-			}, c.Delimiter); err != nil {
+			}); err != nil {
 				fmt.Printf("[Nuke] Failed to despawn cube %s: %v\n", cube, err)
 			}
 		}
 
-		fmt.Printf("[Nuke] NUKED %d cubes (pass %d)\n", len(cubes), attempt)
+		fmt.Printf("[Nuke] NUKED %d cubes on %s (pass %d)\n", len(cubes), pool.ServerAddr, attempt)
 		time.Sleep(500 * time.Millisecond)
 	}
 
-	fmt.Println("[Nuke] Finished.")
+	fmt.Println("[Nuke] Finished on", pool.ServerAddr)
 }
 
 func (c *Construct[T]) UnfreezeAll() {
-	conn, err := net.Dial("tcp", c.ServerAddr)
-	if err != nil {
-		fmt.Println("[UnfreezeAll] Failed to connect:", err)
-		return
+	var wg sync.WaitGroup
+	for _, pool := range c.targetPools() {
+		wg.Add(1)
+		go func(pool *Pool) {
+			defer wg.Done()
+			unfreezeAllOnPool(pool)
+		}(pool)
 	}
-	defer conn.Close()
+	wg.Wait()
+}
 
-	if _, err := conn.Write([]byte(c.AuthPass + c.Delimiter)); err != nil {
-		fmt.Println("[UnfreezeAll] Failed to auth:", err)
+func unfreezeAllOnPool(pool *Pool) {
+	pc, err := pool.Get()
+	if err != nil {
+		fmt.Println("[UnfreezeAll] Failed to connect:", err)
 		return
 	}
-	_, _ = readResponse(conn, c.Delimiter)
+	broken := false
+	defer func() { pool.Put(pc, broken) }()
 
 	const maxRetries = 3
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if err := sendJSONMessage(conn, Message{"type": "get_cube_list"}, c.Delimiter); err != nil {
+		if err := pc.Send(Message{"type": "get_cube_list"}); err != nil {
 			fmt.Println("[UnfreezeAll] Failed to request cube list:", err)
+			broken = true
 			return
 		}
-		raw, err := readResponse(conn, c.Delimiter)
+		raw, err := pc.Recv()
 		if err != nil {
 			fmt.Println("[UnfreezeAll] Failed to read cube list:", err)
+			broken = true
 			return
 		}
 
@@ -143,45 +311,72 @@ func (c *Construct[T]) UnfreezeAll() {
 
 		cubes := toStringArray(cubeData["cubes"])
 		if len(cubes) == 0 {
-			fmt.Println("[UnfreezeAll] No cubes to unfreeze.")
+			fmt.Println("[UnfreezeAll] No cubes to unfreeze on", pool.ServerAddr)
 			return
 		}
 
 		for _, cube := range cubes {
-			if err := sendJSONMessage(conn, Message{
+			if err := pc.Send(Message{
 				"type":      "freeze_cube",
 				"cube_name": cube,
 				"freeze":    false,
-			}, c.Delimiter); err != nil {
+			}); err != nil {
 				fmt.Printf("[UnfreezeAll] Failed to unfreeze cube %s: %v\n", cube, err)
 			}
 		}
 
-		fmt.Printf("[UnfreezeAll] Unfroze %d cubes (pass %d)\n", len(cubes), attempt)
+		fmt.Printf("[UnfreezeAll] Unfroze %d cubes on %s (pass %d)\n", len(cubes), pool.ServerAddr, attempt)
 		time.Sleep(200 * time.Millisecond)
 	}
 }
 
 func (c *Construct[T]) GetAllCubeNames() ([]string, error) {
-	conn, err := net.Dial("tcp", c.ServerAddr)
-	if err != nil {
-		return nil, fmt.Errorf("[GetAllCubeNames] Failed to connect: %w", err)
+	pools := c.targetPools()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var names []string
+	var errs []error
+
+	for _, pool := range pools {
+		wg.Add(1)
+		go func(pool *Pool) {
+			defer wg.Done()
+			got, err := getCubeNamesOnPool(pool)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			names = append(names, got...)
+		}(pool)
 	}
-	defer conn.Close()
+	wg.Wait()
 
-	// Authenticate
-	if _, err := conn.Write([]byte(c.AuthPass + c.Delimiter)); err != nil {
-		return nil, fmt.Errorf("[GetAllCubeNames] Auth failed: %w", err)
+	if len(names) == 0 && len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return names, nil
+}
+
+func getCubeNamesOnPool(pool *Pool) ([]string, error) {
+	pc, err := pool.Get()
+	if err != nil {
+		return nil, fmt.Errorf("[GetAllCubeNames] Failed to connect: %w", err)
 	}
-	_, _ = readResponse(conn, c.Delimiter)
+	broken := false
+	defer func() { pool.Put(pc, broken) }()
 
 	// Request cube list
-	if err := sendJSONMessage(conn, Message{"type": "get_cube_list"}, c.Delimiter); err != nil {
+	if err := pc.Send(Message{"type": "get_cube_list"}); err != nil {
+		broken = true
 		return nil, fmt.Errorf("[GetAllCubeNames] Failed to request cube list: %w", err)
 	}
 
-	raw, err := readResponse(conn, c.Delimiter)
+	raw, err := pc.Recv()
 	if err != nil {
+		broken = true
 		return nil, fmt.Errorf("[GetAllCubeNames] Failed to read response: %w", err)
 	}
 
@@ -190,8 +385,7 @@ func (c *Construct[T]) GetAllCubeNames() ([]string, error) {
 		return nil, fmt.Errorf("[GetAllCubeNames] JSON unmarshal error: %w", err)
 	}
 
-	cubeNames := toStringArray(cubeData["cubes"])
-	return cubeNames, nil
+	return toStringArray(cubeData["cubes"]), nil
 }
 
 func (c *Construct[T]) StartPulsing(actionsPerSecond int, duration time.Duration) {
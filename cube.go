@@ -1,24 +1,70 @@
 package construct
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"net"
+	"time"
+
+	paragon "github.com/OpenFluke/PARAGON"
 )
 
-func (c *Cube[T]) Spawn() error {
-	conn, err := net.Dial("tcp", c.ServerAddr)
+// connect establishes the cube's persistent connection (via its Pool if set,
+// or a direct dial otherwise) and negotiates a codec, without issuing any
+// sim-server command.
+func (c *Cube[T]) connect() error {
+	if c.Pool != nil {
+		pc, err := c.Pool.Get()
+		if err != nil {
+			return fmt.Errorf("❌ [%s] connect failed: %w", c.Name, err)
+		}
+		c.connMu.Lock()
+		c.pc = pc
+		c.conn = pc.Conn()
+		c.reader = pc.Reader()
+		c.codec = pc.Codec()
+		c.connMu.Unlock()
+		return nil
+	}
+
+	dialed, err := net.Dial("tcp", c.ServerAddr)
 	if err != nil {
 		return fmt.Errorf("❌ [%s] connect failed: %w", c.Name, err)
 	}
 
-	if _, err := conn.Write([]byte(c.AuthPass + c.Delimiter)); err != nil {
-		conn.Close()
+	if _, err := dialed.Write([]byte(c.AuthPass + c.Delimiter)); err != nil {
+		dialed.Close()
 		return fmt.Errorf("❌ [%s] auth failed: %w", c.Name, err)
 	}
-	_, _ = readResponse(conn, c.Delimiter)
 
-	c.conn = conn // Save the persistent connection
+	reader := bufio.NewReader(dialed)
+	legacy := LegacyDelimitedCodec{Delimiter: c.Delimiter}
+	dialed.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+	_, _ = legacy.Decode(reader) // discard the auth ack
+
+	codec := negotiateCodec(dialed, reader, c.Delimiter, c.Codec)
+	c.connMu.Lock()
+	c.conn = dialed
+	c.reader = reader
+	c.codec = codec
+	c.connMu.Unlock()
+	return nil
+}
+
+// connSnapshot returns the cube's current connection, reader, and codec
+// under connMu, safe to call concurrently with connect/closeConn swapping
+// them out from another goroutine (e.g. subscribeLoop's reconnect).
+func (c *Cube[T]) connSnapshot() (net.Conn, *bufio.Reader, Codec) {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.conn, c.reader, c.codec
+}
+
+func (c *Cube[T]) Spawn() error {
+	if err := c.connect(); err != nil {
+		return err
+	}
 
 	cmd := Message{
 		"type":      "spawn_cube",
@@ -27,8 +73,10 @@ func (c *Cube[T]) Spawn() error {
 		"rotation":  []float64{0, 0, 0},
 		"is_base":   true,
 	}
-	if err := sendJSONMessage(c.conn, cmd, c.Delimiter); err != nil {
-		c.conn.Close()
+	_, err := c.send(cmd)
+	c.Metrics.observeSpawn(c.Name, c.UnitName, err)
+	if err != nil {
+		c.closeConn(true)
 		return fmt.Errorf("❌ [%s] spawn failed: %w", c.Name, err)
 	}
 
@@ -38,44 +86,150 @@ func (c *Cube[T]) Spawn() error {
 }
 
 func (c *Cube[T]) Despawn() error {
-	conn, err := net.Dial("tcp", c.ServerAddr)
-	if err != nil {
-		return fmt.Errorf("❌ [%s] connect failed: %w", c.Name, err)
-	}
-	defer conn.Close()
+	var pc *PoolConn
+	var conn net.Conn
+	var reader *bufio.Reader
+	var codec Codec
 
-	// Authenticate
-	if _, err := conn.Write([]byte(c.AuthPass + c.Delimiter)); err != nil {
-		return fmt.Errorf("❌ [%s] auth failed: %w", c.Name, err)
+	if c.Pool != nil {
+		got, err := c.Pool.Get()
+		if err != nil {
+			return fmt.Errorf("❌ [%s] connect failed: %w", c.Name, err)
+		}
+		pc = got
+		conn = pc.Conn()
+		reader = pc.Reader()
+		codec = pc.Codec()
+	} else {
+		dialed, err := net.Dial("tcp", c.ServerAddr)
+		if err != nil {
+			return fmt.Errorf("❌ [%s] connect failed: %w", c.Name, err)
+		}
+		defer dialed.Close()
+
+		if _, err := dialed.Write([]byte(c.AuthPass + c.Delimiter)); err != nil {
+			return fmt.Errorf("❌ [%s] auth failed: %w", c.Name, err)
+		}
+
+		reader = bufio.NewReader(dialed)
+		legacy := LegacyDelimitedCodec{Delimiter: c.Delimiter}
+		dialed.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+		_, _ = legacy.Decode(reader) // discard the auth ack
+
+		conn = dialed
+		codec = negotiateCodec(dialed, reader, c.Delimiter, c.Codec)
 	}
-	_, _ = readResponse(conn, c.Delimiter)
 
 	// Send despawn command
 	cmd := Message{
 		"type":      "despawn_cube",
 		"cube_name": c.Name,
 	}
-	if err := sendJSONMessage(conn, cmd, c.Delimiter); err != nil {
+	data, err := codec.Encode(cmd)
+	if err == nil {
+		_, err = conn.Write(data)
+	}
+	c.Metrics.observeDespawn(c.Name, c.UnitName, err)
+	if err != nil {
+		if pc != nil {
+			c.Pool.Put(pc, true)
+		}
 		return fmt.Errorf("❌ [%s] send failed: %w", c.Name, err)
 	}
 
+	if pc != nil {
+		c.Pool.Put(pc, false)
+	}
+
 	fmt.Printf("💣 Despawned cube %s\n", c.Name)
 	return nil
 }
 
+// closeConn tears down the cube's persistent connection, returning it to the
+// pool (marked broken when requested) if it was checked out from one.
+func (c *Cube[T]) closeConn(broken bool) {
+	c.connMu.Lock()
+	pc := c.pc
+	conn := c.conn
+	c.pc = nil
+	c.conn = nil
+	c.reader = nil
+	c.codec = nil
+	c.connMu.Unlock()
+
+	if pc != nil {
+		c.Pool.Put(pc, broken)
+		return
+	}
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+// send encodes and writes msg over the cube's persistent connection.
+func (c *Cube[T]) send(msg Message) (int, error) {
+	conn, _, codec := c.connSnapshot()
+	if conn == nil || codec == nil {
+		return 0, fmt.Errorf("❌ [%s] no connection", c.Name)
+	}
+	data, err := codec.Encode(msg)
+	if err != nil {
+		return 0, err
+	}
+	return conn.Write(data)
+}
+
+// PulseWithModel runs the cube's model forward and applies the resulting
+// force. If the cube is subscribed (see Subscribe), it costs one write and
+// no read: position comes from the subscription's cache instead of a
+// synchronous get_cube_state round-trip.
 func (c *Cube[T]) PulseWithModel() error {
-	if c.conn == nil {
+	if conn, _, _ := c.connSnapshot(); conn == nil {
 		return fmt.Errorf("❌ [%s] no connection", c.Name)
 	}
+	start := time.Now()
+
+	force, err := c.forceFromModel()
+	if err != nil {
+		return err
+	}
+
+	msg := Message{
+		"type":  "apply_force",
+		"force": force,
+	}
+	_, sendErr := c.send(msg)
+	c.Metrics.observeApplyForce(c.Name, c.UnitName, sendErr)
+	if sendErr != nil {
+		return fmt.Errorf("❌ [%s] apply_force failed: %w", c.Name, sendErr)
+	}
+
+	c.posMu.Lock()
+	subscribed := c.subscribed
+	c.posMu.Unlock()
+	if subscribed {
+		c.Metrics.observePulseLatency(c.Name, c.UnitName, time.Since(start))
+		return nil
+	}
+
+	err = c.RefreshPosition()
+	c.Metrics.observePulseLatency(c.Name, c.UnitName, time.Since(start))
+	return err
+}
 
+// forceFromModel runs the cube's model forward on its current position and
+// returns the resulting force vector, clamped to [ClampMin, ClampMax].
+func (c *Cube[T]) forceFromModel() ([]float64, error) {
+	c.posMu.Lock()
 	input := [][]float64{
 		{c.Position[0], c.Position[1], c.Position[2]},
 	}
+	c.posMu.Unlock()
 	c.Model.Forward(input)
 	output := c.Model.GetOutput()
 
 	if len(output) < 3 {
-		return fmt.Errorf("❌ [%s] model output too short", c.Name)
+		return nil, fmt.Errorf("❌ [%s] model output too short", c.Name)
 	}
 
 	force := make([]float64, 3)
@@ -89,47 +243,56 @@ func (c *Cube[T]) PulseWithModel() error {
 		}
 		force[i] = v
 	}
-
-	msg := Message{
-		"type":  "apply_force",
-		"force": force,
-	}
-	if err := sendJSONMessage(c.conn, msg, c.Delimiter); err != nil {
-		return fmt.Errorf("❌ [%s] apply_force failed: %w", c.Name, err)
-	}
-
-	return c.RefreshPosition()
+	return force, nil
 }
 
 func (c *Cube[T]) RefreshPosition() error {
-	if c.conn == nil {
+	conn, reader, codec := c.connSnapshot()
+	if conn == nil {
 		return fmt.Errorf("❌ [%s] no connection", c.Name)
 	}
+	start := time.Now()
 
 	request := Message{"type": "get_cube_state"}
-	if err := sendJSONMessage(c.conn, request, c.Delimiter); err != nil {
+	if _, err := c.send(request); err != nil {
 		return fmt.Errorf("❌ [%s] state request failed: %w", c.Name, err)
 	}
 
-	raw, err := readResponse(c.conn, c.Delimiter)
+	conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+	raw, err := codec.Decode(reader)
+	c.Metrics.observeRefreshRTT(c.Name, c.UnitName, time.Since(start))
 	if err != nil {
 		return fmt.Errorf("❌ [%s] state read failed: %w", c.Name, err)
 	}
 
-	var state map[string]interface{}
+	var state Message
 	if err := json.Unmarshal([]byte(raw), &state); err != nil {
 		return fmt.Errorf("❌ [%s] JSON parse error: %w", c.Name, err)
 	}
 
+	if err := applyPositionState(c, state); err != nil {
+		return err
+	}
+	c.Metrics.observePosition(c.Name, c.UnitName, c.Position)
+	return nil
+}
+
+// applyPositionState copies the "position" field of a get_cube_state reply
+// into the cube's Position, if present and well-formed. Guarded by posMu so
+// it's safe to call from the subscribeLoop goroutine as well as the
+// synchronous RefreshPosition path.
+func applyPositionState[T paragon.Numeric](c *Cube[T], state Message) error {
 	pos, ok := state["position"].([]interface{})
 	if !ok || len(pos) != 3 {
 		return fmt.Errorf("❌ [%s] invalid position format", c.Name)
 	}
 
+	c.posMu.Lock()
 	for i := 0; i < 3; i++ {
 		if val, ok := pos[i].(float64); ok {
 			c.Position[i] = val
 		}
 	}
+	c.posMu.Unlock()
 	return nil
 }
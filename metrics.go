@@ -0,0 +1,178 @@
+package construct
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics registers Prometheus collectors for Construct/Cube activity and
+// serves them via an http.Handler, replacing the common pattern of hand
+// rolling a scores map and a monitor goroutine around a sim run. Every
+// per-cube metric is labelled with cube_name and unit_name so multi-species
+// experiments stay separable in Grafana; the pool gauge is labelled by
+// server_addr instead.
+type Metrics struct {
+	Goal []float64 // target position used for the distance-to-goal gauge
+
+	registry *prometheus.Registry
+
+	position        *prometheus.GaugeVec
+	distanceToGoal  *prometheus.GaugeVec
+	pulseLatency    *prometheus.HistogramVec
+	refreshRTT      *prometheus.HistogramVec
+	spawnTotal      *prometheus.CounterVec
+	despawnTotal    *prometheus.CounterVec
+	applyForceTotal *prometheus.CounterVec
+	poolInUse       *prometheus.GaugeVec
+}
+
+// NewMetrics creates and registers the construct collector set.
+func NewMetrics() *Metrics {
+	reg := prometheus.NewRegistry()
+
+	m := &Metrics{
+		registry: reg,
+		position: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "construct",
+			Name:      "cube_position",
+			Help:      "Current cube position, one gauge per axis.",
+		}, []string{"cube_name", "unit_name", "axis"}),
+		distanceToGoal: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "construct",
+			Name:      "cube_distance_to_goal",
+			Help:      "Euclidean distance from the cube's current position to Metrics.Goal.",
+		}, []string{"cube_name", "unit_name"}),
+		pulseLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "construct",
+			Name:      "pulse_with_model_seconds",
+			Help:      "Latency of PulseWithModel, from forward pass through apply_force, plus the state refresh when the cube is not subscribed.",
+		}, []string{"cube_name", "unit_name"}),
+		refreshRTT: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "construct",
+			Name:      "refresh_position_rtt_seconds",
+			Help:      "Round-trip time of a get_cube_state request.",
+		}, []string{"cube_name", "unit_name"}),
+		spawnTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "construct",
+			Name:      "spawn_total",
+			Help:      "Count of spawn_cube attempts by result.",
+		}, []string{"cube_name", "unit_name", "result"}),
+		despawnTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "construct",
+			Name:      "despawn_total",
+			Help:      "Count of despawn_cube attempts by result.",
+		}, []string{"cube_name", "unit_name", "result"}),
+		applyForceTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "construct",
+			Name:      "apply_force_total",
+			Help:      "Count of apply_force attempts by result.",
+		}, []string{"cube_name", "unit_name", "result"}),
+		poolInUse: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "construct",
+			Name:      "pool_in_use_connections",
+			Help:      "Connections currently checked out of a server's pool.",
+		}, []string{"server_addr"}),
+	}
+
+	reg.MustRegister(
+		m.position,
+		m.distanceToGoal,
+		m.pulseLatency,
+		m.refreshRTT,
+		m.spawnTotal,
+		m.despawnTotal,
+		m.applyForceTotal,
+		m.poolInUse,
+	)
+	return m
+}
+
+// Handler returns an http.Handler serving these metrics in the Prometheus
+// text exposition format, suitable for mounting at e.g. "/metrics".
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+func (m *Metrics) observeSpawn(cubeName, unitName string, err error) {
+	if m == nil {
+		return
+	}
+	m.spawnTotal.WithLabelValues(cubeName, unitName, resultLabel(err)).Inc()
+}
+
+func (m *Metrics) observeDespawn(cubeName, unitName string, err error) {
+	if m == nil {
+		return
+	}
+	m.despawnTotal.WithLabelValues(cubeName, unitName, resultLabel(err)).Inc()
+}
+
+func (m *Metrics) observeApplyForce(cubeName, unitName string, err error) {
+	if m == nil {
+		return
+	}
+	m.applyForceTotal.WithLabelValues(cubeName, unitName, resultLabel(err)).Inc()
+}
+
+func (m *Metrics) observePulseLatency(cubeName, unitName string, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.pulseLatency.WithLabelValues(cubeName, unitName).Observe(d.Seconds())
+}
+
+func (m *Metrics) observeRefreshRTT(cubeName, unitName string, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.refreshRTT.WithLabelValues(cubeName, unitName).Observe(d.Seconds())
+}
+
+func (m *Metrics) observePosition(cubeName, unitName string, pos []float64) {
+	if m == nil {
+		return
+	}
+	axes := []string{"x", "y", "z"}
+	for i, v := range pos {
+		if i >= len(axes) {
+			break
+		}
+		m.position.WithLabelValues(cubeName, unitName, axes[i]).Set(v)
+	}
+
+	if len(m.Goal) == 0 {
+		return
+	}
+	m.distanceToGoal.WithLabelValues(cubeName, unitName).Set(distance(pos, m.Goal))
+}
+
+func (m *Metrics) observePoolStats(serverAddr string, stats PoolStats) {
+	if m == nil {
+		return
+	}
+	m.poolInUse.WithLabelValues(serverAddr).Set(float64(stats.InUse))
+}
+
+func distance(a, b []float64) float64 {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
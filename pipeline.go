@@ -0,0 +1,199 @@
+package construct
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	paragon "github.com/OpenFluke/PARAGON"
+)
+
+// Pipeline batches several messages onto a single connection, writing them
+// back-to-back and then reading all replies in order, the same way
+// go-redis's Pipeliner avoids a round-trip per command. Replies are matched
+// back to their request via the "id" field Queue stamps onto each message.
+type Pipeline struct {
+	pc     *PoolConn
+	queued []Message
+	nextID int64
+}
+
+// NewPipeline creates a Pipeline that writes to and reads from pc, using its
+// negotiated codec and shared bufio.Reader.
+func NewPipeline(pc *PoolConn) *Pipeline {
+	return &Pipeline{pc: pc}
+}
+
+// Queue stamps msg with a sequence id and adds it to the batch, returning
+// that id so the caller can correlate it with the matching reply in Exec's
+// result slice.
+func (p *Pipeline) Queue(msg Message) int64 {
+	p.nextID++
+	id := p.nextID
+	msg["id"] = id
+	p.queued = append(p.queued, msg)
+	return id
+}
+
+// Exec writes every queued message in one pass and then reads back that many
+// replies, returning them in the same order the messages were queued
+// (reordering by the "id" field if the server's replies arrive out of
+// order). The queue is cleared afterward so the Pipeline can be reused.
+func (p *Pipeline) Exec() ([]Message, error) {
+	if len(p.queued) == 0 {
+		return nil, nil
+	}
+	queued := p.queued
+
+	for _, msg := range queued {
+		if err := p.pc.Send(msg); err != nil {
+			p.queued = nil
+			return nil, fmt.Errorf("[Pipeline] write failed: %w", err)
+		}
+	}
+
+	replies := make([]Message, 0, len(queued))
+	for range queued {
+		raw, err := p.pc.Recv()
+		if err != nil {
+			p.queued = nil
+			return nil, fmt.Errorf("[Pipeline] read failed: %w", err)
+		}
+
+		var reply Message
+		if err := json.Unmarshal([]byte(raw), &reply); err != nil {
+			p.queued = nil
+			return nil, fmt.Errorf("[Pipeline] JSON unmarshal error: %w", err)
+		}
+		replies = append(replies, reply)
+	}
+
+	p.queued = nil
+	return matchReplies(queued, replies), nil
+}
+
+// matchReplies lines replies back up with the order queued was sent in,
+// matching each reply to the queued message carrying the same "id" field
+// Queue stamped on (as a float64, since that's how json.Unmarshal decodes a
+// bare number). Any reply without a recognized id — a legacy server that
+// doesn't echo it back — is assigned in arrival order to whichever queued
+// slots are left.
+func matchReplies(queued, replies []Message) []Message {
+	byID := make(map[int64]Message, len(replies))
+	var unmatched []Message
+	for _, reply := range replies {
+		if id, ok := reply["id"].(float64); ok {
+			byID[int64(id)] = reply
+			continue
+		}
+		unmatched = append(unmatched, reply)
+	}
+
+	results := make([]Message, len(queued))
+	for i, msg := range queued {
+		id, _ := msg["id"].(int64)
+		if reply, ok := byID[id]; ok {
+			results[i] = reply
+		} else if len(unmatched) > 0 {
+			results[i] = unmatched[0]
+			unmatched = unmatched[1:]
+		}
+	}
+	return results
+}
+
+// PulseAllPipelined drives every cube for one tick in as few round-trips as
+// possible: cubes are grouped by the connection pool they actually live on
+// (a shard's pool when c is sharded, the single default pool otherwise), and
+// each group runs its own pipeline concurrently. Within a group this queues
+// an apply_force for each cube, executes the batch, then queues and executes
+// a get_cube_state per cube to refresh positions - two round-trips per pool
+// per tick instead of two per cube.
+func (c *Construct[T]) PulseAllPipelined() error {
+	byPool := make(map[*Pool][]*Cube[T])
+	for _, cube := range c.Cubes {
+		pool := cube.Pool
+		if pool == nil {
+			pool = c.pool()
+		}
+		byPool[pool] = append(byPool[pool], cube)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for pool, cubes := range byPool {
+		wg.Add(1)
+		go func(pool *Pool, cubes []*Cube[T]) {
+			defer wg.Done()
+			if err := pulsePipelinedOnPool(pool, cubes); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(pool, cubes)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// pulsePipelinedOnPool runs one PulseAllPipelined tick for cubes that share
+// pool, over a single connection checked out from it.
+func pulsePipelinedOnPool[T paragon.Numeric](pool *Pool, cubes []*Cube[T]) error {
+	pc, err := pool.Get()
+	if err != nil {
+		return fmt.Errorf("[PulseAllPipelined] connect to %s failed: %w", pool.ServerAddr, err)
+	}
+	broken := false
+	defer func() { pool.Put(pc, broken) }()
+
+	pipe := NewPipeline(pc)
+
+	for _, cube := range cubes {
+		force, err := cube.forceFromModel()
+		if err != nil {
+			fmt.Printf("❌ [%s] %v\n", cube.Name, err)
+			continue
+		}
+		pipe.Queue(Message{"type": "apply_force", "cube_name": cube.Name, "force": force})
+	}
+	if _, err := pipe.Exec(); err != nil {
+		broken = true
+		return fmt.Errorf("[PulseAllPipelined] apply_force batch on %s failed: %w", pool.ServerAddr, err)
+	}
+
+	for _, cube := range cubes {
+		pipe.Queue(Message{"type": "get_cube_state", "cube_name": cube.Name})
+	}
+	states, err := pipe.Exec()
+	if err != nil {
+		broken = true
+		return fmt.Errorf("[PulseAllPipelined] get_cube_state batch on %s failed: %w", pool.ServerAddr, err)
+	}
+
+	byCubeName := make(map[string]Message, len(states))
+	for _, state := range states {
+		if state == nil {
+			continue
+		}
+		if name, ok := state["cube_name"].(string); ok {
+			byCubeName[name] = state
+		}
+	}
+
+	for _, cube := range cubes {
+		state, ok := byCubeName[cube.Name]
+		if !ok {
+			continue
+		}
+		if err := applyPositionState(cube, state); err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	return nil
+}
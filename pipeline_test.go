@@ -0,0 +1,77 @@
+package construct
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPipelineQueueAssignsSequentialIDs(t *testing.T) {
+	p := &Pipeline{}
+	id1 := p.Queue(Message{"type": "apply_force"})
+	id2 := p.Queue(Message{"type": "get_cube_state"})
+	if id1 != 1 || id2 != 2 {
+		t.Fatalf("Queue ids = %d, %d; want 1, 2", id1, id2)
+	}
+	if p.queued[0]["id"] != int64(1) || p.queued[1]["id"] != int64(2) {
+		t.Fatalf("Queue didn't stamp msg[\"id\"]: %+v", p.queued)
+	}
+}
+
+func TestMatchRepliesInOrder(t *testing.T) {
+	queued := []Message{
+		{"id": int64(1)},
+		{"id": int64(2)},
+		{"id": int64(3)},
+	}
+	replies := []Message{
+		{"id": float64(1), "ok": true},
+		{"id": float64(2), "ok": true},
+		{"id": float64(3), "ok": true},
+	}
+	got := matchReplies(queued, replies)
+	for i, r := range got {
+		if r["id"] != float64(i+1) {
+			t.Fatalf("result[%d] = %v, want id %d", i, r, i+1)
+		}
+	}
+}
+
+func TestMatchRepliesOutOfOrder(t *testing.T) {
+	queued := []Message{
+		{"id": int64(1)},
+		{"id": int64(2)},
+		{"id": int64(3)},
+	}
+	// Server replies in reverse order.
+	replies := []Message{
+		{"id": float64(3)},
+		{"id": float64(1)},
+		{"id": float64(2)},
+	}
+	got := matchReplies(queued, replies)
+	for i, r := range got {
+		if r["id"] != float64(i+1) {
+			t.Fatalf("result[%d] = %v, want id %d (replies should be reordered by id)", i, r, i+1)
+		}
+	}
+}
+
+func TestMatchRepliesFallsBackToArrivalOrderWithoutID(t *testing.T) {
+	queued := []Message{
+		{"id": int64(1)},
+		{"id": int64(2)},
+	}
+	// A legacy server that doesn't echo "id" back at all.
+	replies := []Message{
+		{"state": "a"},
+		{"state": "b"},
+	}
+	got := matchReplies(queued, replies)
+	want := []Message{
+		{"state": "a"},
+		{"state": "b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("matchReplies = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,336 @@
+package construct
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// defaultIdleTimeout is how long a lazily created Pool lets a connection sit
+// idle before closing it instead of handing it out again.
+const defaultIdleTimeout = 30 * time.Second
+
+// defaultReadTimeout bounds how long a single Recv/Decode call will block
+// waiting for the sim server to reply, matching the original readResponse's
+// 3-second deadline so a stalled server surfaces as an error instead of
+// hanging the caller forever.
+const defaultReadTimeout = 3 * time.Second
+
+// defaultPoolTimeout bounds how long Get waits for a connection to free up
+// once a Pool is at MaxSize, the same bounded-wait behavior go-redis's pool
+// gives Get under contention, mirrored here instead of failing instantly.
+const defaultPoolTimeout = 3 * time.Second
+
+// PoolStats reports point-in-time counters for a Pool, suitable for periodic
+// logging or scraping into an external metrics system.
+type PoolStats struct {
+	Hits     int64 // checkouts served by an idle connection
+	Misses   int64 // checkouts that required a fresh dial
+	Timeouts int64 // checkouts that gave up waiting for a free slot
+	InUse    int64 // connections currently checked out
+}
+
+// PoolConn wraps a connection handed out by a Pool along with the bookkeeping
+// needed to return it later. It carries the bufio.Reader and Codec that were
+// negotiated for it at dial time, so repeated reads reuse one buffer instead
+// of allocating a new reader per call.
+type PoolConn struct {
+	conn     net.Conn
+	reader   *bufio.Reader
+	codec    Codec
+	lastUsed time.Time
+}
+
+// Conn returns the underlying authenticated net.Conn.
+func (pc *PoolConn) Conn() net.Conn {
+	return pc.conn
+}
+
+// Reader returns the bufio.Reader bound to this connection.
+func (pc *PoolConn) Reader() *bufio.Reader {
+	return pc.reader
+}
+
+// Codec returns the codec negotiated for this connection.
+func (pc *PoolConn) Codec() Codec {
+	return pc.codec
+}
+
+// Send encodes and writes msg using this connection's negotiated codec.
+func (pc *PoolConn) Send(msg Message) error {
+	data, err := pc.codec.Encode(msg)
+	if err != nil {
+		return err
+	}
+	_, err = pc.conn.Write(data)
+	return err
+}
+
+// Recv reads and returns the next raw JSON payload using this connection's
+// negotiated codec and shared bufio.Reader. Bounded by defaultReadTimeout so
+// a server that stops responding surfaces as an error instead of blocking
+// the caller forever.
+func (pc *PoolConn) Recv() (string, error) {
+	pc.conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+	return pc.codec.Decode(pc.reader)
+}
+
+// Pool is a bounded pool of authenticated TCP connections to a single
+// construct sim server, modeled on go-redis's connection pool: idle
+// connections are reused across calls, health-checked on checkout, and
+// re-authenticated automatically after a reconnect.
+type Pool struct {
+	ServerAddr  string
+	AuthPass    string
+	Delimiter   string
+	MaxSize     int
+	IdleTimeout time.Duration
+
+	// Timeout bounds how long Get blocks waiting for a connection to free up
+	// once the pool is at MaxSize, rather than failing instantly. Defaults to
+	// defaultPoolTimeout if left zero.
+	Timeout time.Duration
+
+	// OnDial is called, if set, after a new TCP connection is established
+	// but before authentication is attempted.
+	OnDial func(net.Conn)
+	// OnAuthFail is called, if set, whenever authentication against the
+	// sim server fails.
+	OnAuthFail func(error)
+
+	// Codec is the preferred framing to negotiate on each new connection.
+	// Defaults to LegacyDelimitedCodec if left nil.
+	Codec Codec
+
+	// StatsHook, if set, is called with a fresh Stats() snapshot after every
+	// Get and Put, so callers (e.g. Metrics) can track in-use connections
+	// without polling.
+	StatsHook func(PoolStats)
+
+	mu      sync.Mutex
+	idle    []*PoolConn
+	numOpen int
+	stats   PoolStats
+
+	// waitCh, if non-nil, is closed (and cleared) by signalRelease the next
+	// time a slot frees up, waking every Get call currently waiting on it.
+	waitCh chan struct{}
+}
+
+// NewPool creates a Pool targeting a single server. maxSize caps the number
+// of connections (idle + in-use) the pool will ever open; idleTimeout
+// controls how long an idle connection may sit before it is closed instead
+// of being handed out again.
+func NewPool(addr, pass, delim string, maxSize int, idleTimeout time.Duration) *Pool {
+	if maxSize <= 0 {
+		maxSize = 16
+	}
+	return &Pool{
+		ServerAddr:  addr,
+		AuthPass:    pass,
+		Delimiter:   delim,
+		MaxSize:     maxSize,
+		IdleTimeout: idleTimeout,
+	}
+}
+
+// Get checks out a healthy, authenticated connection, reusing an idle one
+// when possible and dialing+authenticating a new one otherwise. Once the
+// pool is at MaxSize with nothing idle, Get blocks until a connection is
+// returned via Put/Close or p.Timeout (default defaultPoolTimeout) elapses.
+func (p *Pool) Get() (*PoolConn, error) {
+	defer p.notifyStats()
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = defaultPoolTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		p.mu.Lock()
+		for len(p.idle) > 0 {
+			pc := p.idle[len(p.idle)-1]
+			p.idle = p.idle[:len(p.idle)-1]
+
+			if p.IdleTimeout > 0 && time.Since(pc.lastUsed) > p.IdleTimeout {
+				p.numOpen--
+				p.mu.Unlock()
+				pc.conn.Close()
+				p.mu.Lock()
+				continue
+			}
+
+			if !connHealthy(pc) {
+				p.numOpen--
+				p.mu.Unlock()
+				pc.conn.Close()
+				p.mu.Lock()
+				continue
+			}
+
+			p.stats.Hits++
+			p.stats.InUse++
+			p.mu.Unlock()
+			return pc, nil
+		}
+
+		if p.numOpen < p.MaxSize {
+			p.numOpen++
+			p.mu.Unlock()
+
+			pc, err := p.dial()
+			if err != nil {
+				p.mu.Lock()
+				p.numOpen--
+				p.mu.Unlock()
+				p.signalRelease()
+				return nil, err
+			}
+
+			p.mu.Lock()
+			p.stats.Misses++
+			p.stats.InUse++
+			p.mu.Unlock()
+			return pc, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			p.stats.Timeouts++
+			p.mu.Unlock()
+			return nil, fmt.Errorf("[Pool] timed out after %s waiting for a free connection (%d already open)", timeout, p.numOpen)
+		}
+		ch := p.waitChLocked()
+		p.mu.Unlock()
+
+		select {
+		case <-ch:
+		case <-time.After(remaining):
+		}
+	}
+}
+
+// waitChLocked returns the channel Get should block on until a slot frees
+// up, creating one if none is pending. Must be called with p.mu held.
+func (p *Pool) waitChLocked() chan struct{} {
+	if p.waitCh == nil {
+		p.waitCh = make(chan struct{})
+	}
+	return p.waitCh
+}
+
+// signalRelease wakes every Get call currently waiting for a slot, if any.
+func (p *Pool) signalRelease() {
+	p.mu.Lock()
+	ch := p.waitCh
+	p.waitCh = nil
+	p.mu.Unlock()
+	if ch != nil {
+		close(ch)
+	}
+}
+
+// Put returns a connection to the pool. If broken is true, the connection is
+// closed instead of being reused; otherwise it goes back on the idle list.
+// Either way, a slot just freed up, so any Get blocked waiting on one is
+// woken.
+func (p *Pool) Put(pc *PoolConn, broken bool) {
+	if pc == nil {
+		return
+	}
+	defer p.notifyStats()
+	defer p.signalRelease()
+
+	p.mu.Lock()
+	p.stats.InUse--
+	if broken {
+		p.numOpen--
+		p.mu.Unlock()
+		pc.conn.Close()
+		return
+	}
+
+	pc.lastUsed = time.Now()
+	p.idle = append(p.idle, pc)
+	p.mu.Unlock()
+}
+
+// Stats returns a snapshot of the pool's counters.
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+func (p *Pool) notifyStats() {
+	if p.StatsHook == nil {
+		return
+	}
+	p.StatsHook(p.Stats())
+}
+
+// Close closes every idle connection in the pool. Connections checked out at
+// the time are not closed by Close; a later non-broken Put puts them back on
+// the idle list like any other, and the pool stays usable by Get.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.numOpen -= len(idle)
+	p.mu.Unlock()
+
+	for _, pc := range idle {
+		pc.conn.Close()
+	}
+	p.signalRelease()
+}
+
+func (p *Pool) dial() (*PoolConn, error) {
+	conn, err := net.Dial("tcp", p.ServerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("[Pool] dial failed: %w", err)
+	}
+
+	if p.OnDial != nil {
+		p.OnDial(conn)
+	}
+
+	if _, err := conn.Write([]byte(p.AuthPass + p.Delimiter)); err != nil {
+		conn.Close()
+		if p.OnAuthFail != nil {
+			p.OnAuthFail(err)
+		}
+		return nil, fmt.Errorf("[Pool] auth failed: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	legacy := LegacyDelimitedCodec{Delimiter: p.Delimiter}
+	conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+	_, _ = legacy.Decode(reader) // discard the auth ack
+
+	codec := negotiateCodec(conn, reader, p.Delimiter, p.Codec)
+
+	return &PoolConn{conn: conn, reader: reader, codec: codec, lastUsed: time.Now()}, nil
+}
+
+// connHealthy does a best-effort liveness check on an idle connection: a
+// zero-wait peek that should time out on a live, quiet socket but return EOF
+// (or another error) on one the peer has closed. It peeks through pc's
+// bufio.Reader rather than reading the raw conn, so any unsolicited byte it
+// sees stays buffered for the next real Decode instead of being dropped.
+func connHealthy(pc *PoolConn) bool {
+	pc.conn.SetReadDeadline(time.Now().Add(time.Millisecond))
+	defer pc.conn.SetReadDeadline(time.Time{})
+
+	_, err := pc.reader.Peek(1)
+	if err == nil {
+		// Unexpected unread data is still a sign of life.
+		return true
+	}
+	if ne, ok := err.(net.Error); ok && ne.Timeout() {
+		return true
+	}
+	return false
+}
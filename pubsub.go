@@ -0,0 +1,212 @@
+package construct
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	paragon "github.com/OpenFluke/PARAGON"
+)
+
+// subscribeRetryDelay is how long subscribeLoop waits before re-dialing after
+// a transient TCP drop.
+const subscribeRetryDelay = time.Second
+
+// CubeStateEvent reports a position update pushed by the sim server to a
+// subscribed cube, or the error that ended its subscription.
+type CubeStateEvent struct {
+	CubeName string
+	Position []float64
+	Err      error
+}
+
+// Subscribe sends subscribe_cube_state once over the cube's persistent
+// connection, then starts a background goroutine that reads server-pushed
+// state frames into the cube's position cache (guarded by posMu) until ctx
+// is cancelled. Once subscribed, PulseWithModel reads from this cache
+// instead of issuing a synchronous get_cube_state, so a pulse costs one
+// write and no read. Transient TCP drops are retried with a fresh connect +
+// re-subscribe rather than ending the subscription.
+func (c *Cube[T]) Subscribe(ctx context.Context) error {
+	if conn, _, _ := c.connSnapshot(); conn == nil {
+		return fmt.Errorf("❌ [%s] no connection", c.Name)
+	}
+	if err := c.sendSubscribe(); err != nil {
+		return fmt.Errorf("❌ [%s] subscribe failed: %w", c.Name, err)
+	}
+
+	c.posMu.Lock()
+	c.subscribed = true
+	c.posMu.Unlock()
+
+	go c.subscribeLoop(ctx)
+	return nil
+}
+
+// sendSubscribe writes the subscribe_cube_state command over the cube's
+// current connection.
+func (c *Cube[T]) sendSubscribe() error {
+	_, err := c.send(Message{"type": "subscribe_cube_state", "cube_name": c.Name})
+	return err
+}
+
+// subscribeLoop reads pushed state frames off the cube's connection,
+// applying each to the position cache, and transparently reconnects +
+// re-subscribes on a transient drop. It exits when ctx is cancelled.
+func (c *Cube[T]) subscribeLoop(ctx context.Context) {
+	defer func() {
+		c.posMu.Lock()
+		c.subscribed = false
+		c.posMu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		conn, reader, codec := c.connSnapshot()
+		conn.SetReadDeadline(time.Now().Add(defaultReadTimeout))
+		raw, err := codec.Decode(reader)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				// No push within the deadline is normal idle behavior, not a
+				// dead connection; just poll again.
+				continue
+			}
+			c.closeConn(true)
+			if !c.resubscribe(ctx) {
+				return
+			}
+			continue
+		}
+
+		var state Message
+		if err := json.Unmarshal([]byte(raw), &state); err != nil {
+			fmt.Printf("❌ [%s] subscribe JSON parse error: %v\n", c.Name, err)
+			continue
+		}
+		if err := applyPositionState(c, state); err != nil {
+			continue
+		}
+		c.Metrics.observePosition(c.Name, c.UnitName, c.snapshotPosition())
+	}
+}
+
+// resubscribe blocks, retrying on subscribeRetryDelay, until it re-dials and
+// re-sends subscribe_cube_state or ctx is cancelled. Returns false if ctx was
+// cancelled before reconnecting succeeded.
+func (c *Cube[T]) resubscribe(ctx context.Context) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(subscribeRetryDelay):
+		}
+
+		if err := c.connect(); err != nil {
+			continue
+		}
+		if err := c.sendSubscribe(); err != nil {
+			c.closeConn(true)
+			continue
+		}
+		return true
+	}
+}
+
+// snapshotPosition returns a copy of the cube's current position, safe to
+// read concurrently with subscribeLoop's writes.
+func (c *Cube[T]) snapshotPosition() []float64 {
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+	pos := make([]float64, len(c.Position))
+	copy(pos, c.Position)
+	return pos
+}
+
+// SubscribeAll subscribes every cube in the Construct and multiplexes their
+// CubeStateEvents onto a single channel. The channel is closed once ctx is
+// cancelled and every cube's subscription has wound down. It is buffered to
+// give slow consumers some slack; once it is full, each cube's forwarder
+// blocks until the consumer catches up (backpressure). A cube's subscribeLoop
+// keeps updating its position cache meanwhile, so positions that were
+// overwritten before the forwarder resumed are not delivered; only the
+// latest one is.
+func (c *Construct[T]) SubscribeAll(ctx context.Context) <-chan CubeStateEvent {
+	out := make(chan CubeStateEvent, 256)
+
+	var wg sync.WaitGroup
+	for _, cube := range c.Cubes {
+		if err := cube.Subscribe(ctx); err != nil {
+			// Send off the hot path: with hundreds of cubes failing up front
+			// (e.g. the sim server is down at startup), a synchronous send
+			// here would block once the 256-deep buffer fills, since nothing
+			// drains it until SubscribeAll returns the channel to its caller.
+			wg.Add(1)
+			go func(cube *Cube[T], err error) {
+				defer wg.Done()
+				select {
+				case out <- CubeStateEvent{CubeName: cube.Name, Err: err}:
+				case <-ctx.Done():
+				}
+			}(cube, err)
+			continue
+		}
+		wg.Add(1)
+		go func(cube *Cube[T]) {
+			defer wg.Done()
+			forwardCubeEvents(ctx, cube, out)
+		}(cube)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// forwardCubeEvents polls cube's position cache every 50ms and forwards each
+// change to out until ctx is cancelled.
+func forwardCubeEvents[T paragon.Numeric](ctx context.Context, cube *Cube[T], out chan<- CubeStateEvent) {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	var last []float64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pos := cube.snapshotPosition()
+			if positionsEqual(last, pos) {
+				continue
+			}
+			last = pos
+			select {
+			case out <- CubeStateEvent{CubeName: cube.Name, Position: pos}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func positionsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
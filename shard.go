@@ -0,0 +1,308 @@
+package construct
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+	"sync"
+
+	paragon "github.com/OpenFluke/PARAGON"
+)
+
+// shardReplicas is the number of virtual nodes placed on the ring per real
+// server, smoothing out how evenly cubes distribute across servers.
+const shardReplicas = 100
+
+// ServerEndpoint describes one sim server in a sharded Construct. Each cube
+// is routed to exactly one endpoint via a consistent-hash ring keyed on the
+// cube's name (or HashTag, if present).
+type ServerEndpoint struct {
+	Addr      string
+	AuthPass  string
+	Delimiter string
+	Codec     Codec
+
+	// PoolSize overrides the size of this endpoint's connection pool. Left at
+	// 0, poolFor sizes the pool from the current cube count instead of a flat
+	// default, since a shard's pool has to hold both its cubes' persistent
+	// connections and the occasional admin/migration checkout.
+	PoolSize int
+}
+
+// shardPoolHeadroom is added on top of the cube count when poolFor sizes a
+// shard's pool by cube count, leaving room for concurrent admin fan-out
+// calls (DestroyAllCubes, UnfreezeAll, GetAllCubeNames) and migration
+// handshakes against that endpoint.
+const shardPoolHeadroom = 4
+
+// HashTag returns the substring of name that should be hashed for shard
+// placement: the contents of the first `{...}` pair if present, otherwise
+// name itself. Wrapping a shared substring in `{...}` across several cube
+// names forces them onto the same shard, the same trick go-redis Cluster
+// uses for hash tags.
+func HashTag(name string) string {
+	start := strings.IndexByte(name, '{')
+	if start < 0 {
+		return name
+	}
+	end := strings.IndexByte(name[start+1:], '}')
+	if end < 0 {
+		return name
+	}
+	if tag := name[start+1 : start+1+end]; tag != "" {
+		return tag
+	}
+	return name
+}
+
+type ringNode struct {
+	hash uint32
+	ep   *ServerEndpoint
+}
+
+// shardRing is a consistent-hash ring over ServerEndpoints, the same
+// technique go-redis Cluster uses for slot placement.
+type shardRing struct {
+	mu    sync.RWMutex
+	nodes []ringNode
+}
+
+func newShardRing() *shardRing {
+	return &shardRing{}
+}
+
+func (r *shardRing) add(ep *ServerEndpoint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := 0; i < shardReplicas; i++ {
+		h := crc32.ChecksumIEEE([]byte(fmt.Sprintf("%s#%d", ep.Addr, i)))
+		r.nodes = append(r.nodes, ringNode{hash: h, ep: ep})
+	}
+	sort.Slice(r.nodes, func(i, j int) bool { return r.nodes[i].hash < r.nodes[j].hash })
+}
+
+func (r *shardRing) remove(addr string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.nodes[:0]
+	for _, n := range r.nodes {
+		if n.ep.Addr != addr {
+			kept = append(kept, n)
+		}
+	}
+	r.nodes = kept
+}
+
+func (r *shardRing) ownerOf(key string) *ServerEndpoint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.nodes) == 0 {
+		return nil
+	}
+	h := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(r.nodes), func(i int) bool { return r.nodes[i].hash >= h })
+	if i == len(r.nodes) {
+		i = 0
+	}
+	return r.nodes[i].ep
+}
+
+// shardFor returns the ServerEndpoint that owns cubeName, or nil if sharding
+// isn't configured (c.Servers is empty), building the ring from c.Servers on
+// first use. c.mu only guards building and snapshotting the c.ring pointer
+// itself - the ring's own node list has its own internal lock - so ownerOf
+// runs outside the Construct lock.
+func (c *Construct[T]) shardFor(cubeName string) *ServerEndpoint {
+	c.mu.Lock()
+	if c.ring == nil && len(c.Servers) > 0 {
+		c.ring = newShardRing()
+		for i := range c.Servers {
+			ep := c.Servers[i]
+			c.ring.add(&ep)
+		}
+	}
+	ring := c.ring
+	c.mu.Unlock()
+
+	if ring == nil {
+		return nil
+	}
+	return ring.ownerOf(HashTag(cubeName))
+}
+
+// poolFor returns (creating if necessary) the connection pool dedicated to
+// ep, keyed by ep.Addr.
+func (c *Construct[T]) poolFor(ep *ServerEndpoint) *Pool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shardPools == nil {
+		c.shardPools = make(map[string]*Pool)
+	}
+	if p, ok := c.shardPools[ep.Addr]; ok {
+		return p
+	}
+
+	size := ep.PoolSize
+	if size <= 0 {
+		size = len(c.Cubes) + shardPoolHeadroom
+		if size < defaultPoolSize {
+			size = defaultPoolSize
+		}
+	}
+	p := NewPool(ep.Addr, ep.AuthPass, ep.Delimiter, size, defaultIdleTimeout)
+	p.Codec = ep.Codec
+	c.wireMetrics(p)
+	c.shardPools[ep.Addr] = p
+	return p
+}
+
+// AddServer adds a sim server to the shard ring and rebalances only the
+// cubes whose hash now lands on it, migrating each via a spawn_cube +
+// despawn_cube handshake against the old and new owners.
+func (c *Construct[T]) AddServer(ep ServerEndpoint) error {
+	before := c.ownersByName()
+
+	c.mu.Lock()
+	if c.ring == nil {
+		c.ring = newShardRing()
+	}
+	ring := c.ring
+	c.mu.Unlock()
+
+	epCopy := ep
+	ring.add(&epCopy)
+
+	c.mu.Lock()
+	c.Servers = append(c.Servers, ep)
+	c.mu.Unlock()
+
+	c.rebalance(before)
+	return nil
+}
+
+// RemoveServer removes a sim server from the shard ring and migrates its
+// cubes to whichever server now owns their hash.
+func (c *Construct[T]) RemoveServer(addr string) error {
+	before := c.ownersByName()
+
+	c.mu.Lock()
+	ring := c.ring
+	c.mu.Unlock()
+	if ring != nil {
+		ring.remove(addr)
+	}
+
+	c.mu.Lock()
+	servers := c.Servers[:0]
+	for _, ep := range c.Servers {
+		if ep.Addr != addr {
+			servers = append(servers, ep)
+		}
+	}
+	c.Servers = servers
+	pool, had := c.shardPools[addr]
+	delete(c.shardPools, addr)
+	c.mu.Unlock()
+
+	if had {
+		pool.Close()
+	}
+
+	c.rebalance(before)
+	return nil
+}
+
+// cubeShardKey returns the identifier cube was actually hashed against the
+// ring with at spawn time, falling back to its current Name for cubes that
+// haven't gone through SpawnAll's sharded path yet (shardKey unset).
+func cubeShardKey[T paragon.Numeric](cube *Cube[T]) string {
+	if cube.shardKey != "" {
+		return cube.shardKey
+	}
+	return cube.Name
+}
+
+// ownersByName snapshots the current shard owner of every cube, keyed by
+// cubeShardKey, for comparison after a ring mutation.
+func (c *Construct[T]) ownersByName() map[string]*ServerEndpoint {
+	owners := make(map[string]*ServerEndpoint, len(c.Cubes))
+	for _, cube := range c.Cubes {
+		owners[cubeShardKey(cube)] = c.shardFor(cubeShardKey(cube))
+	}
+	return owners
+}
+
+// rebalance migrates every cube whose owner changed relative to before. Owner
+// lookups are keyed by cubeShardKey rather than cube.Name, since Spawn
+// appends "_BASE" to Name and that suffix generally hashes to a different
+// ring position than the name the cube was actually placed under.
+func (c *Construct[T]) rebalance(before map[string]*ServerEndpoint) {
+	for _, cube := range c.Cubes {
+		key := cubeShardKey(cube)
+		oldEp := before[key]
+		newEp := c.shardFor(key)
+		if oldEp == nil || newEp == nil || oldEp.Addr == newEp.Addr {
+			continue
+		}
+		if err := c.migrateCube(cube, oldEp, newEp); err != nil {
+			fmt.Printf("❌ [Shard] failed to migrate %s from %s to %s: %v\n", cube.Name, oldEp.Addr, newEp.Addr, err)
+		}
+	}
+}
+
+// migrateCube spawns cubeName on newEp and despawns it from oldEp, then
+// repoints the cube at its new home (including reconnecting its persistent
+// data connection) so both future admin calls and in-flight
+// PulseWithModel/RefreshPosition calls target newEp.
+func (c *Construct[T]) migrateCube(cube *Cube[T], oldEp, newEp *ServerEndpoint) error {
+	newPool := c.poolFor(newEp)
+	pc, err := newPool.Get()
+	if err != nil {
+		return fmt.Errorf("spawn on %s failed: %w", newEp.Addr, err)
+	}
+	spawnErr := pc.Send(Message{
+		"type":      "spawn_cube",
+		"cube_name": cube.Name,
+		"position":  cube.Position,
+		"rotation":  []float64{0, 0, 0},
+		"is_base":   true,
+	})
+	newPool.Put(pc, spawnErr != nil)
+	if spawnErr != nil {
+		return fmt.Errorf("spawn on %s failed: %w", newEp.Addr, spawnErr)
+	}
+
+	oldPool := c.poolFor(oldEp)
+	opc, err := oldPool.Get()
+	if err != nil {
+		return fmt.Errorf("despawn on %s failed: %w", oldEp.Addr, err)
+	}
+	despawnErr := opc.Send(Message{"type": "despawn_cube", "cube_name": cube.Name})
+	oldPool.Put(opc, despawnErr != nil)
+	if despawnErr != nil {
+		return fmt.Errorf("despawn on %s failed: %w", oldEp.Addr, despawnErr)
+	}
+
+	// The cube's persistent data connection still points at oldEp, which
+	// just despawned it; close it before repointing so PulseWithModel/
+	// RefreshPosition don't keep writing apply_force against a cube that no
+	// longer exists there.
+	cube.closeConn(false)
+
+	cube.ServerAddr = newEp.Addr
+	cube.AuthPass = newEp.AuthPass
+	cube.Delimiter = newEp.Delimiter
+	cube.Pool = newPool
+
+	if err := cube.connect(); err != nil {
+		fmt.Printf("⚠️  [Shard] %s migrated to %s but reconnect failed: %v\n", cube.Name, newEp.Addr, err)
+	}
+
+	fmt.Printf("🔀 [Shard] migrated %s: %s -> %s\n", cube.Name, oldEp.Addr, newEp.Addr)
+	return nil
+}
@@ -0,0 +1,83 @@
+package construct
+
+import "testing"
+
+func TestParseURLSingleHost(t *testing.T) {
+	c, err := ParseURL[float64]("construct://secret@127.0.0.1:14000/?delim=%23&clampMin=-5&clampMax=5&pool=4&codec=length")
+	if err != nil {
+		t.Fatalf("ParseURL: %v", err)
+	}
+	if c.ServerAddr != "127.0.0.1:14000" || c.AuthPass != "secret" || c.Delimiter != "#" {
+		t.Fatalf("unexpected connection fields: %+v", c)
+	}
+	if c.ClampMin != -5 || c.ClampMax != 5 {
+		t.Fatalf("unexpected clamp fields: min=%v max=%v", c.ClampMin, c.ClampMax)
+	}
+	if c.Pool == nil || c.Pool.MaxSize != 4 {
+		t.Fatalf("pool size not threaded through: %+v", c.Pool)
+	}
+	if c.sharded() {
+		t.Fatal("single host should not be sharded")
+	}
+}
+
+func TestParseURLDefaults(t *testing.T) {
+	c, err := ParseURL[float64]("construct://secret@127.0.0.1:14000/")
+	if err != nil {
+		t.Fatalf("ParseURL: %v", err)
+	}
+	if c.Delimiter != "-" || c.ClampMin != -20 || c.ClampMax != 20 {
+		t.Fatalf("unexpected defaults: %+v", c)
+	}
+	if c.Pool == nil || c.Pool.MaxSize != defaultPoolSize {
+		t.Fatalf("expected default pool size %d, got %+v", defaultPoolSize, c.Pool)
+	}
+}
+
+func TestParseURLMultiHostShardsWithoutExplicitPool(t *testing.T) {
+	c, err := ParseURL[float64]("construct://secret@h1:14000,h2:14000/")
+	if err != nil {
+		t.Fatalf("ParseURL: %v", err)
+	}
+	if !c.sharded() {
+		t.Fatal("multiple hosts should put the Construct in sharded mode")
+	}
+	if len(c.Servers) != 2 {
+		t.Fatalf("Servers = %+v, want 2 endpoints", c.Servers)
+	}
+	for _, ep := range c.Servers {
+		if ep.AuthPass != "secret" {
+			t.Fatalf("endpoint missing AuthPass: %+v", ep)
+		}
+		if ep.PoolSize != 0 {
+			t.Fatalf("PoolSize should stay 0 (cube-count default) when pool= wasn't given, got %d", ep.PoolSize)
+		}
+	}
+}
+
+func TestParseURLMultiHostThreadsExplicitPoolSize(t *testing.T) {
+	c, err := ParseURL[float64]("construct://secret@h1:14000,h2:14000/?pool=64")
+	if err != nil {
+		t.Fatalf("ParseURL: %v", err)
+	}
+	for _, ep := range c.Servers {
+		if ep.PoolSize != 64 {
+			t.Fatalf("PoolSize = %d, want 64 threaded through from the pool= query param", ep.PoolSize)
+		}
+	}
+}
+
+func TestParseURLErrors(t *testing.T) {
+	cases := []string{
+		"redis://secret@h1:1/",
+		"construct://secret@/",
+		"construct://secret@h1:1/?clampMin=nope",
+		"construct://secret@h1:1/?pool=nope",
+		"construct://secret@h1:1/?codec=bogus",
+	}
+	for _, raw := range cases {
+		if _, err := ParseURL[float64](raw); err == nil {
+			t.Errorf("ParseURL(%q) = nil error, want error", raw)
+		}
+	}
+}